@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+import (
+	"testing"
+
+	"github.com/uber/jaeger-client-go/thrift-gen/sampling"
+)
+
+type testCountingReporter struct {
+	counts map[string]int64
+}
+
+func newTestCountingReporter() *testCountingReporter {
+	return &testCountingReporter{counts: make(map[string]int64)}
+}
+
+func (r *testCountingReporter) IncCounter(name string, tags map[string]string, value int64) {
+	r.counts[name] += value
+}
+
+func (r *testCountingReporter) UpdateGauge(name string, tags map[string]string, value int64) {}
+
+func newTestAdaptiveSampler(t *testing.T, maxOperations int, metrics *Metrics) *adaptiveSampler {
+	t.Helper()
+	strategies := &sampling.PerOperationSamplingStrategies{
+		DefaultSamplingProbability:       0.5,
+		DefaultLowerBoundTracesPerSecond: 1,
+	}
+	s, err := NewAdaptiveSampler(strategies, maxOperations, metrics)
+	if err != nil {
+		t.Fatalf("NewAdaptiveSampler failed: %v", err)
+	}
+	return s.(*adaptiveSampler)
+}
+
+func TestAdaptiveSamplerEvictsLeastRecentlySampled(t *testing.T) {
+	reporter := newTestCountingReporter()
+	s := newTestAdaptiveSampler(t, 2, NewMetrics(reporter, nil))
+
+	s.IsSampled(1, "a")
+	s.IsSampled(1, "b")
+	if _, ok := s.samplers["a"]; !ok {
+		t.Fatal("expected operation a to be tracked")
+	}
+	if _, ok := s.samplers["b"]; !ok {
+		t.Fatal("expected operation b to be tracked")
+	}
+
+	// "a" is now the least-recently-sampled; a third operation should evict it.
+	s.IsSampled(1, "c")
+
+	if _, ok := s.samplers["a"]; ok {
+		t.Fatal("expected operation a to have been evicted")
+	}
+	if _, ok := s.samplers["b"]; !ok {
+		t.Fatal("expected operation b to still be tracked")
+	}
+	if _, ok := s.samplers["c"]; !ok {
+		t.Fatal("expected operation c to be tracked")
+	}
+	if s.lruList.Len() != 2 {
+		t.Fatalf("expected LRU list to have 2 entries, got %d", s.lruList.Len())
+	}
+	if got := reporter.counts["sampler.operations.evicted"]; got != 1 {
+		t.Fatalf("expected SamplerOperationsEvicted to be incremented once, got %d", got)
+	}
+}
+
+func TestAdaptiveSamplerRecencyIsRefreshedOnSample(t *testing.T) {
+	s := newTestAdaptiveSampler(t, 2, NewMetrics(NullStatsReporter, nil))
+
+	s.IsSampled(1, "a")
+	s.IsSampled(1, "b")
+	// Re-sampling "a" makes it most-recently-used, so "b" becomes the
+	// eviction candidate instead.
+	s.IsSampled(1, "a")
+	s.IsSampled(1, "c")
+
+	if _, ok := s.samplers["b"]; ok {
+		t.Fatal("expected operation b to have been evicted")
+	}
+	if _, ok := s.samplers["a"]; !ok {
+		t.Fatal("expected operation a to still be tracked")
+	}
+}
+
+func TestAdaptiveSamplerUpdateDoesNotDisturbRecency(t *testing.T) {
+	s := newTestAdaptiveSampler(t, 2, NewMetrics(NullStatsReporter, nil))
+
+	s.IsSampled(1, "a")
+	s.IsSampled(1, "b")
+	// "a" is the least-recently-sampled entry.
+
+	err := s.update(&sampling.PerOperationSamplingStrategies{
+		DefaultSamplingProbability:       0.5,
+		DefaultLowerBoundTracesPerSecond: 1,
+		PerOperationStrategies: []*sampling.OperationSamplingStrategy{
+			{
+				Operation:             "a",
+				ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.9},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	// Merging a's new strategy must not promote it to most-recently-used, so
+	// it is still the one evicted when a third operation is sampled.
+	s.IsSampled(1, "c")
+
+	if _, ok := s.samplers["a"]; ok {
+		t.Fatal("expected operation a to have been evicted despite the update")
+	}
+	if _, ok := s.samplers["b"]; !ok {
+		t.Fatal("expected operation b to still be tracked")
+	}
+}
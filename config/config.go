@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package config allows a Sampler to be bootstrapped from environment
+// variables or a config file, instead of being assembled programmatically
+// with SamplerOptions. This is intended for deployments managed by a
+// container orchestrator, where recompiling to change sampling behavior
+// is impractical.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber/jaeger-client-go"
+)
+
+// Sampler type names accepted by SamplerConfig.Type.
+const (
+	samplerTypeConst         = "const"
+	samplerTypeProbabilistic = "probabilistic"
+	samplerTypeRateLimiting  = "ratelimiting"
+	samplerTypeRemote        = "remote"
+
+	defaultSamplingProbability = 0.001
+)
+
+// SamplerConfig describes the sampler to construct, typically populated by
+// SamplerConfigFromEnv or by unmarshalling a YAML/JSON config file into it.
+type SamplerConfig struct {
+	// Type is one of "const", "probabilistic", "ratelimiting", or "remote".
+	// An empty Type defaults to "remote".
+	Type string `yaml:"type" json:"type"`
+
+	// Param is the sampler parameter: 0 or 1 for "const", a sampling
+	// probability in [0, 1] for "probabilistic" and "remote", or a
+	// traces-per-second rate for "ratelimiting".
+	Param float64 `yaml:"param" json:"param"`
+
+	// SamplingServerURL is the host:port of the local sampling server,
+	// e.g. jaeger-agent, polled when Type is "remote".
+	SamplingServerURL string `yaml:"samplingServerURL" json:"samplingServerURL"`
+
+	// SamplingRefreshInterval is how often the "remote" sampler polls
+	// SamplingServerURL for an updated strategy. Defaults to 1 minute.
+	SamplingRefreshInterval time.Duration `yaml:"samplingRefreshInterval" json:"samplingRefreshInterval"`
+
+	// MaxOperations is the maximum number of operations the "remote"
+	// sampler will track when the server returns a per-operation strategy.
+	MaxOperations int `yaml:"maxOperations" json:"maxOperations"`
+}
+
+// NewSampler constructs a jaeger.Sampler from the configuration.
+func (sc *SamplerConfig) NewSampler(serviceName string, metrics *jaeger.Metrics) (jaeger.Sampler, error) {
+	switch sc.samplerType() {
+	case samplerTypeConst:
+		return jaeger.NewConstSampler(sc.Param != 0), nil
+	case samplerTypeProbabilistic:
+		return jaeger.NewProbabilisticSampler(sc.Param)
+	case samplerTypeRateLimiting:
+		return jaeger.NewRateLimitingSampler(sc.Param), nil
+	case samplerTypeRemote:
+		return sc.newRemoteSampler(serviceName, metrics)
+	}
+	return nil, fmt.Errorf("unknown sampler type %q", sc.Type)
+}
+
+func (sc *SamplerConfig) newRemoteSampler(serviceName string, metrics *jaeger.Metrics) (jaeger.Sampler, error) {
+	initialRate := sc.Param
+	if initialRate <= 0 {
+		initialRate = defaultSamplingProbability
+	}
+	initialSampler, err := jaeger.NewProbabilisticSampler(initialRate)
+	if err != nil {
+		return nil, err
+	}
+	options := []jaeger.SamplerOption{
+		jaeger.SamplerOptions.Metrics(metrics),
+		jaeger.SamplerOptions.Sampler(initialSampler),
+	}
+	if sc.SamplingServerURL != "" {
+		options = append(options, jaeger.SamplerOptions.HostPort(sc.SamplingServerURL))
+	}
+	if sc.MaxOperations != 0 {
+		options = append(options, jaeger.SamplerOptions.MaxOperations(sc.MaxOperations))
+	}
+	if sc.SamplingRefreshInterval != 0 {
+		options = append(options, jaeger.SamplerOptions.SamplingRefreshInterval(sc.SamplingRefreshInterval))
+	}
+	return jaeger.NewRemotelyControlledSampler(serviceName, options...), nil
+}
+
+func (sc *SamplerConfig) samplerType() string {
+	if sc.Type == "" {
+		return samplerTypeRemote
+	}
+	return sc.Type
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by SamplerConfigFromEnv.
+const (
+	envSamplerType            = "JAEGER_SAMPLER_TYPE"
+	envSamplerParam           = "JAEGER_SAMPLER_PARAM"
+	envSamplerManagerHostPort = "JAEGER_SAMPLER_MANAGER_HOST_PORT"
+	envSamplerMaxOperations   = "JAEGER_SAMPLER_MAX_OPERATIONS"
+	envSamplerRefreshInterval = "JAEGER_SAMPLER_REFRESH_INTERVAL"
+)
+
+// SamplerConfigFromEnv reads the JAEGER_SAMPLER_* environment variables into
+// a SamplerConfig. Variables that are unset leave the corresponding field at
+// its zero value, so callers can overlay this onto defaults or a file-based
+// config before calling NewSampler.
+func SamplerConfigFromEnv() (*SamplerConfig, error) {
+	sc := &SamplerConfig{}
+	if e := os.Getenv(envSamplerType); e != "" {
+		sc.Type = e
+	}
+	if e := os.Getenv(envSamplerParam); e != "" {
+		value, err := strconv.ParseFloat(e, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse env var %s=%s: %v", envSamplerParam, e, err)
+		}
+		sc.Param = value
+	}
+	if e := os.Getenv(envSamplerManagerHostPort); e != "" {
+		sc.SamplingServerURL = e
+	}
+	if e := os.Getenv(envSamplerMaxOperations); e != "" {
+		value, err := strconv.Atoi(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse env var %s=%s: %v", envSamplerMaxOperations, e, err)
+		}
+		sc.MaxOperations = value
+	}
+	if e := os.Getenv(envSamplerRefreshInterval); e != "" {
+		value, err := time.ParseDuration(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse env var %s=%s: %v", envSamplerRefreshInterval, e, err)
+		}
+		sc.SamplingRefreshInterval = value
+	}
+	return sc, nil
+}
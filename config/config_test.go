@@ -0,0 +1,159 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uber/jaeger-client-go"
+)
+
+func TestSamplerConfigFromEnvDefaults(t *testing.T) {
+	sc, err := SamplerConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *sc != (SamplerConfig{}) {
+		t.Fatalf("expected a zero-value SamplerConfig when no env vars are set, got %+v", sc)
+	}
+}
+
+func TestSamplerConfigFromEnvPopulatesFields(t *testing.T) {
+	t.Setenv(envSamplerType, "probabilistic")
+	t.Setenv(envSamplerParam, "0.25")
+	t.Setenv(envSamplerManagerHostPort, "jaeger-agent:5778")
+	t.Setenv(envSamplerMaxOperations, "100")
+	t.Setenv(envSamplerRefreshInterval, "30s")
+
+	sc, err := SamplerConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &SamplerConfig{
+		Type:                    "probabilistic",
+		Param:                   0.25,
+		SamplingServerURL:       "jaeger-agent:5778",
+		MaxOperations:           100,
+		SamplingRefreshInterval: 30 * time.Second,
+	}
+	if *sc != *want {
+		t.Fatalf("expected %+v, got %+v", want, sc)
+	}
+}
+
+func TestSamplerConfigFromEnvParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		val  string
+	}{
+		{"bad param", envSamplerParam, "not-a-float"},
+		{"bad max operations", envSamplerMaxOperations, "not-an-int"},
+		{"bad refresh interval", envSamplerRefreshInterval, "not-a-duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.val)
+			if _, err := SamplerConfigFromEnv(); err == nil {
+				t.Fatalf("expected an error parsing %s=%s", tt.env, tt.val)
+			}
+		})
+	}
+}
+
+func TestSamplerConfigNewSampler(t *testing.T) {
+	metrics := jaeger.NewMetrics(jaeger.NullStatsReporter, nil)
+
+	t.Run("const", func(t *testing.T) {
+		sc := &SamplerConfig{Type: "const", Param: 1}
+		sampler, err := sc.NewSampler("svc", metrics)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sampler.Close()
+		if _, ok := sampler.(*jaeger.ConstSampler); !ok {
+			t.Fatalf("expected a ConstSampler, got %T", sampler)
+		}
+	})
+
+	t.Run("probabilistic", func(t *testing.T) {
+		sc := &SamplerConfig{Type: "probabilistic", Param: 0.5}
+		sampler, err := sc.NewSampler("svc", metrics)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sampler.Close()
+		if _, ok := sampler.(*jaeger.ProbabilisticSampler); !ok {
+			t.Fatalf("expected a ProbabilisticSampler, got %T", sampler)
+		}
+	})
+
+	t.Run("probabilistic invalid param", func(t *testing.T) {
+		sc := &SamplerConfig{Type: "probabilistic", Param: 2}
+		if _, err := sc.NewSampler("svc", metrics); err == nil {
+			t.Fatal("expected an error for an out-of-range sampling rate")
+		}
+	})
+
+	t.Run("ratelimiting", func(t *testing.T) {
+		sc := &SamplerConfig{Type: "ratelimiting", Param: 3}
+		sampler, err := sc.NewSampler("svc", metrics)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sampler.Close()
+	})
+
+	t.Run("remote defaults when type is empty", func(t *testing.T) {
+		sc := &SamplerConfig{}
+		sampler, err := sc.NewSampler("svc", metrics)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sampler.Close()
+		if _, ok := sampler.(*jaeger.RemotelyControlledSampler); !ok {
+			t.Fatalf("expected a RemotelyControlledSampler, got %T", sampler)
+		}
+	})
+
+	t.Run("remote with explicit options", func(t *testing.T) {
+		sc := &SamplerConfig{
+			Type:                    "remote",
+			Param:                   0.75,
+			SamplingServerURL:       "jaeger-agent:5778",
+			MaxOperations:           10,
+			SamplingRefreshInterval: time.Second,
+		}
+		sampler, err := sc.NewSampler("svc", metrics)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer sampler.Close()
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		sc := &SamplerConfig{Type: "bogus"}
+		if _, err := sc.NewSampler("svc", metrics); err == nil {
+			t.Fatal("expected an error for an unknown sampler type")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package grpc provides a gRPC-based implementation of sampling.SamplingManager,
+// for use with jaeger-agent/collector deployments that are only reachable over
+// gRPC rather than plain HTTP.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/uber/jaeger-client-go/proto-gen/api_v2"
+	"github.com/uber/jaeger-client-go/thrift-gen/sampling"
+)
+
+// SamplingManager is a sampling.SamplingManager that fetches the sampling
+// strategy for a service from jaeger-agent/collector over gRPC, as an
+// alternative to the library's default HTTP-based transport. It talks to the
+// server using the generated api_v2 proto client and translates the response
+// into the thrift-gen/sampling types the rest of the library expects.
+type SamplingManager struct {
+	client api_v2.SamplingManagerClient
+}
+
+// NewSamplingManager creates a SamplingManager that issues requests over the
+// given gRPC connection. Dialing, retries, and TLS configuration of conn are
+// the caller's responsibility.
+func NewSamplingManager(conn *grpc.ClientConn) *SamplingManager {
+	return &SamplingManager{client: api_v2.NewSamplingManagerClient(conn)}
+}
+
+// newSamplingManagerFromClient builds a SamplingManager around an arbitrary
+// api_v2.SamplingManagerClient, e.g. an in-memory fake used in tests to
+// exercise GetSamplingStrategy without a running server.
+func newSamplingManagerFromClient(client api_v2.SamplingManagerClient) *SamplingManager {
+	return &SamplingManager{client: client}
+}
+
+// GetSamplingStrategy implements sampling.SamplingManager.
+func (s *SamplingManager) GetSamplingStrategy(serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	resp, err := s.client.GetSamplingStrategy(context.Background(), &api_v2.SamplingStrategyParameters{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toThriftResponse(resp), nil
+}
+
+// toThriftResponse converts a proto SamplingStrategyResponse into the
+// thrift-gen/sampling.SamplingStrategyResponse shape that
+// sampling.SamplingManager implementations are expected to return.
+func toThriftResponse(resp *api_v2.SamplingStrategyResponse) *sampling.SamplingStrategyResponse {
+	out := &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType(resp.StrategyType),
+	}
+	if p := resp.ProbabilisticSampling; p != nil {
+		out.ProbabilisticSampling = &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: p.SamplingRate,
+		}
+	}
+	if r := resp.RateLimitingSampling; r != nil {
+		out.RateLimitingSampling = &sampling.RateLimitingSamplingStrategy{
+			MaxTracesPerSecond: int16(r.MaxTracesPerSecond),
+		}
+	}
+	if o := resp.OperationSampling; o != nil {
+		perOp := &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       o.DefaultSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: o.DefaultLowerBoundTracesPerSecond,
+		}
+		for _, strategy := range o.PerOperationStrategies {
+			operationStrategy := &sampling.OperationSamplingStrategy{
+				Operation: strategy.Operation,
+			}
+			if strategy.ProbabilisticSampling != nil {
+				operationStrategy.ProbabilisticSampling = &sampling.ProbabilisticSamplingStrategy{
+					SamplingRate: strategy.ProbabilisticSampling.SamplingRate,
+				}
+			}
+			perOp.PerOperationStrategies = append(perOp.PerOperationStrategies, operationStrategy)
+		}
+		out.OperationSampling = perOp
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/uber/jaeger-client-go/proto-gen/api_v2"
+)
+
+// fakeManagerServer is an api_v2.SamplingManagerServer used to serve requests
+// over a real gRPC transport in tests, so that SamplingManager is proven to
+// talk to grpc-go's codec rather than an in-memory Go interface fake.
+type fakeManagerServer struct {
+	resp *api_v2.SamplingStrategyResponse
+}
+
+func (f *fakeManagerServer) GetSamplingStrategy(
+	ctx context.Context,
+	in *api_v2.SamplingStrategyParameters,
+) (*api_v2.SamplingStrategyResponse, error) {
+	return f.resp, nil
+}
+
+// dialBufconnSamplingManager starts an in-process gRPC server backed by srv
+// and returns a SamplingManager dialed against it over bufconn, so that the
+// default gRPC codec actually marshals/unmarshals the api_v2 proto types on
+// the wire. The returned func tears down the server and connection.
+func dialBufconnSamplingManager(t *testing.T, srv api_v2.SamplingManagerServer) (*SamplingManager, func()) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	api_v2.RegisterSamplingManagerServer(s, srv)
+	go s.Serve(lis)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+
+	mgr := NewSamplingManager(conn)
+	return mgr, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestSamplingManagerGetSamplingStrategyOverRealGRPC(t *testing.T) {
+	srv := &fakeManagerServer{
+		resp: &api_v2.SamplingStrategyResponse{
+			StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{
+				SamplingRate: 0.5,
+			},
+			OperationSampling: &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability: 0.1,
+				PerOperationStrategies: []*api_v2.OperationSamplingStrategy{
+					{
+						Operation:             "GET /foo",
+						ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.2},
+					},
+				},
+			},
+		},
+	}
+	mgr, teardown := dialBufconnSamplingManager(t, srv)
+	defer teardown()
+
+	resp, err := mgr.GetSamplingStrategy("myservice")
+	if err != nil {
+		t.Fatalf("unexpected error calling over real gRPC transport: %v", err)
+	}
+	if resp.ProbabilisticSampling == nil || resp.ProbabilisticSampling.SamplingRate != 0.5 {
+		t.Fatalf("expected probabilistic sampling rate 0.5, got %+v", resp.ProbabilisticSampling)
+	}
+	if resp.OperationSampling == nil || len(resp.OperationSampling.PerOperationStrategies) != 1 {
+		t.Fatalf("expected 1 per-operation strategy, got %+v", resp.OperationSampling)
+	}
+	if got := resp.OperationSampling.PerOperationStrategies[0].Operation; got != "GET /foo" {
+		t.Fatalf("expected operation %q, got %q", "GET /foo", got)
+	}
+}
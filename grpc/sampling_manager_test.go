@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/uber/jaeger-client-go/proto-gen/api_v2"
+)
+
+// fakeManagerClient is an in-memory api_v2.SamplingManagerClient used to
+// exercise SamplingManager without dialing a real jaeger-agent/collector.
+type fakeManagerClient struct {
+	resp *api_v2.SamplingStrategyResponse
+	err  error
+}
+
+func (f *fakeManagerClient) GetSamplingStrategy(
+	ctx context.Context,
+	in *api_v2.SamplingStrategyParameters,
+	opts ...grpc.CallOption,
+) (*api_v2.SamplingStrategyResponse, error) {
+	return f.resp, f.err
+}
+
+func TestSamplingManagerGetSamplingStrategyProbabilistic(t *testing.T) {
+	fake := &fakeManagerClient{
+		resp: &api_v2.SamplingStrategyResponse{
+			StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{
+				SamplingRate: 0.5,
+			},
+		},
+	}
+	mgr := newSamplingManagerFromClient(fake)
+
+	resp, err := mgr.GetSamplingStrategy("myservice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ProbabilisticSampling == nil || resp.ProbabilisticSampling.SamplingRate != 0.5 {
+		t.Fatalf("expected probabilistic sampling rate 0.5, got %+v", resp.ProbabilisticSampling)
+	}
+}
+
+func TestSamplingManagerGetSamplingStrategyPerOperation(t *testing.T) {
+	fake := &fakeManagerClient{
+		resp: &api_v2.SamplingStrategyResponse{
+			OperationSampling: &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability: 0.1,
+				PerOperationStrategies: []*api_v2.OperationSamplingStrategy{
+					{
+						Operation:             "GET /foo",
+						ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.2},
+					},
+				},
+			},
+		},
+	}
+	mgr := newSamplingManagerFromClient(fake)
+
+	resp, err := mgr.GetSamplingStrategy("myservice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.OperationSampling == nil || len(resp.OperationSampling.PerOperationStrategies) != 1 {
+		t.Fatalf("expected 1 per-operation strategy, got %+v", resp.OperationSampling)
+	}
+	if got := resp.OperationSampling.PerOperationStrategies[0].Operation; got != "GET /foo" {
+		t.Fatalf("expected operation %q, got %q", "GET /foo", got)
+	}
+}
+
+func TestSamplingManagerGetSamplingStrategyError(t *testing.T) {
+	wantErr := context.DeadlineExceeded
+	fake := &fakeManagerClient{err: wantErr}
+	mgr := newSamplingManagerFromClient(fake)
+
+	if _, err := mgr.GetSamplingStrategy("myservice"); err != wantErr {
+		t.Fatalf("expected error %v, got %v", wantErr, err)
+	}
+}
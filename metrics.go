@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+// StatsReporter is the interface that Metrics uses to emit the counters and
+// gauges it tracks to the underlying metrics backend.
+type StatsReporter interface {
+	IncCounter(name string, tags map[string]string, value int64)
+	UpdateGauge(name string, tags map[string]string, value int64)
+}
+
+type nullStatsReporter struct{}
+
+func (nullStatsReporter) IncCounter(name string, tags map[string]string, value int64)  {}
+func (nullStatsReporter) UpdateGauge(name string, tags map[string]string, value int64) {}
+
+// NullStatsReporter is a StatsReporter that discards everything reported to it.
+var NullStatsReporter StatsReporter = nullStatsReporter{}
+
+// Counter is a single named, tagged metric bound to a StatsReporter.
+type Counter struct {
+	name     string
+	tags     map[string]string
+	reporter StatsReporter
+}
+
+// Inc increments the counter by delta.
+func (c Counter) Inc(delta int64) {
+	c.reporter.IncCounter(c.name, c.tags, delta)
+}
+
+// Metrics is a container for the stats emitted by the sampling machinery.
+type Metrics struct {
+	// SamplerRetrieved counts the number of times a sampling strategy was
+	// successfully retrieved from the remote sampling server.
+	SamplerRetrieved Counter
+
+	// SamplerUpdated counts the number of times the in-use Sampler was
+	// successfully updated from a newly retrieved strategy.
+	SamplerUpdated Counter
+
+	// SamplerUpdateFailure counts the number of times updating the in-use
+	// Sampler from a newly retrieved strategy failed.
+	SamplerUpdateFailure Counter
+
+	// SamplerQueryFailure counts the number of times retrieving the sampling
+	// strategy from the remote sampling server failed.
+	SamplerQueryFailure Counter
+
+	// SamplerParsingFailure counts the number of times the retrieved
+	// sampling strategy response could not be parsed into a Sampler.
+	SamplerParsingFailure Counter
+
+	// SamplerOperationsEvicted counts the number of operations evicted from
+	// adaptiveSampler's LRU cache because the number of tracked operations
+	// reached maxOperations.
+	SamplerOperationsEvicted Counter
+}
+
+// NewMetrics creates a Metrics that reports through reporter, with globalTags
+// applied to every counter and gauge.
+func NewMetrics(reporter StatsReporter, globalTags map[string]string) *Metrics {
+	if reporter == nil {
+		reporter = NullStatsReporter
+	}
+	return &Metrics{
+		SamplerRetrieved:         newCounter(reporter, "sampler.queries", globalTags, "result", "ok"),
+		SamplerUpdated:           newCounter(reporter, "sampler.updates", globalTags, "result", "ok"),
+		SamplerUpdateFailure:     newCounter(reporter, "sampler.updates", globalTags, "result", "err"),
+		SamplerQueryFailure:      newCounter(reporter, "sampler.queries", globalTags, "result", "err"),
+		SamplerParsingFailure:    newCounter(reporter, "sampler.errors", globalTags, "phase", "parsing"),
+		SamplerOperationsEvicted: newCounter(reporter, "sampler.operations.evicted", globalTags),
+	}
+}
+
+// newCounter builds a Counter for name, merging globalTags with the
+// name/value pairs passed in extraTags (e.g. "result", "ok").
+func newCounter(reporter StatsReporter, name string, globalTags map[string]string, extraTags ...string) Counter {
+	tags := make(map[string]string, len(globalTags)+len(extraTags)/2)
+	for k, v := range globalTags {
+		tags[k] = v
+	}
+	for i := 0; i+1 < len(extraTags); i += 2 {
+		tags[extraTags[i]] = extraTags[i+1]
+	}
+	return Counter{name: name, tags: tags, reporter: reporter}
+}
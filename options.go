@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+import (
+	"time"
+
+	"github.com/uber/jaeger-client-go/thrift-gen/sampling"
+)
+
+// samplerOptions holds the optional fields accepted by NewRemotelyControlledSampler.
+type samplerOptions struct {
+	metrics         *Metrics
+	maxOperations   int
+	sampler         Sampler
+	logger          Logger
+	hostPort        string
+	manager         sampling.SamplingManager
+	refreshInterval time.Duration
+}
+
+// SamplerOption is a function that sets some option on the sampler
+type SamplerOption func(options *samplerOptions)
+
+// SamplerOptions is a factory for all available SamplerOption's
+var SamplerOptions samplerOptionsFactory
+
+type samplerOptionsFactory struct{}
+
+// Metrics creates a SamplerOption that initializes Metrics on the sampler,
+// which is used to emit statistics.
+func (samplerOptionsFactory) Metrics(m *Metrics) SamplerOption {
+	return func(o *samplerOptions) {
+		o.metrics = m
+	}
+}
+
+// MaxOperations creates a SamplerOption that sets the maximum number of
+// operations the AdaptiveSampler will keep track of.
+func (samplerOptionsFactory) MaxOperations(maxOperations int) SamplerOption {
+	return func(o *samplerOptions) {
+		o.maxOperations = maxOperations
+	}
+}
+
+// Sampler creates a SamplerOption that sets the initial sampler to use
+// before the first remote poll.
+func (samplerOptionsFactory) Sampler(sampler Sampler) SamplerOption {
+	return func(o *samplerOptions) {
+		o.sampler = sampler
+	}
+}
+
+// Logger creates a SamplerOption that sets the logger used by the sampler.
+func (samplerOptionsFactory) Logger(logger Logger) SamplerOption {
+	return func(o *samplerOptions) {
+		o.logger = logger
+	}
+}
+
+// HostPort creates a SamplerOption that sets the host:port of the local
+// sampling server, e.g. jaeger-agent.
+func (samplerOptionsFactory) HostPort(hostPort string) SamplerOption {
+	return func(o *samplerOptions) {
+		o.hostPort = hostPort
+	}
+}
+
+// Manager creates a SamplerOption that sets the sampling.SamplingManager used
+// to fetch sampling strategies from the remote server, overriding the default
+// HTTP-based transport. This allows callers to speak to jaeger-agent/collector
+// over a different transport, e.g. gRPC, or to supply an in-memory fake for
+// testing the poll loop.
+func (samplerOptionsFactory) Manager(manager sampling.SamplingManager) SamplerOption {
+	return func(o *samplerOptions) {
+		o.manager = manager
+	}
+}
+
+// SamplingRefreshInterval creates a SamplerOption that sets how often the
+// remote sampler polls the sampling manager for an updated strategy,
+// overriding the default of 1 minute.
+func (samplerOptionsFactory) SamplingRefreshInterval(refreshInterval time.Duration) SamplerOption {
+	return func(o *samplerOptions) {
+		o.refreshInterval = refreshInterval
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+// SamplerTypePriority is the value of the sampler.type tag applied when a
+// sampling decision was forced by a SamplingHints priority override.
+const SamplerTypePriority = "priority"
+
+// SamplingHints carries out-of-band sampling signals discovered mid-span,
+// e.g. from an OpenTracing sampling.priority tag or a context value set by
+// the caller, that should override a delegate sampler's decision.
+type SamplingHints struct {
+	// Priority, when non-zero, forces a sampling decision: a positive value
+	// forces the trace to be sampled, a negative value forces it to be
+	// dropped, regardless of what the delegate sampler decides. A zero value
+	// means "no hint", leaving the delegate's decision untouched.
+	Priority int
+}
+
+// ContextualSampler is implemented by samplers that can take a SamplingHints
+// value discovered mid-span into account, in addition to the trace id and
+// operation name that Sampler.IsSampled is limited to.
+type ContextualSampler interface {
+	Sampler
+
+	// IsSampledWithContext behaves like IsSampled, but additionally honors
+	// hints, which may force the decision one way or the other.
+	IsSampledWithContext(id uint64, operation string, hints SamplingHints) (sampled bool, tags []Tag)
+}
+
+// PrioritySampler wraps a delegate Sampler and allows a SamplingHints
+// priority to force-sample or force-drop a trace regardless of the
+// delegate's decision. This gives callers a way to debug specific requests,
+// e.g. by setting an OpenTracing sampling.priority tag, without changing the
+// sampling rate for everyone else.
+//
+// A forced decision still consults the delegate sampler first, so that a
+// rateLimitingSampler delegate debits its credit for accounting purposes
+// even though the forced decision bypasses the credit check's verdict.
+type PrioritySampler struct {
+	delegate Sampler
+}
+
+// NewPrioritySampler creates a PrioritySampler that wraps delegate.
+func NewPrioritySampler(delegate Sampler) *PrioritySampler {
+	return &PrioritySampler{delegate: delegate}
+}
+
+// IsSampled implements Sampler by deferring to the delegate with no hints.
+func (s *PrioritySampler) IsSampled(id uint64, operation string) (bool, []Tag) {
+	return s.delegate.IsSampled(id, operation)
+}
+
+// IsSampledWithContext implements ContextualSampler.
+func (s *PrioritySampler) IsSampledWithContext(id uint64, operation string, hints SamplingHints) (bool, []Tag) {
+	sampled, tags := s.delegate.IsSampled(id, operation)
+	if hints.Priority == 0 {
+		return sampled, tags
+	}
+	forced := hints.Priority > 0
+	return forced, priorityTags(forced)
+}
+
+// Close implements Sampler.
+func (s *PrioritySampler) Close() {
+	s.delegate.Close()
+}
+
+// Equal implements Sampler.
+func (s *PrioritySampler) Equal(other Sampler) bool {
+	if o, ok := other.(*PrioritySampler); ok {
+		return s.delegate.Equal(o.delegate)
+	}
+	return false
+}
+
+var (
+	priorityTagsSampled = []Tag{
+		{key: SamplerTypeTagKey, value: SamplerTypePriority},
+		{key: SamplerParamTagKey, value: true},
+	}
+	priorityTagsDropped = []Tag{
+		{key: SamplerTypeTagKey, value: SamplerTypePriority},
+		{key: SamplerParamTagKey, value: false},
+	}
+)
+
+func priorityTags(sampled bool) []Tag {
+	if sampled {
+		return priorityTagsSampled
+	}
+	return priorityTagsDropped
+}
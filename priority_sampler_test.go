@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+import "testing"
+
+// fakeDelegateSampler is a Sampler whose decision and call count are
+// controlled by the test, used to verify that PrioritySampler still
+// consults (and thus debits) the delegate even when it overrides the result.
+type fakeDelegateSampler struct {
+	sampled bool
+	tags    []Tag
+	calls   int
+}
+
+func (f *fakeDelegateSampler) IsSampled(id uint64, operation string) (bool, []Tag) {
+	f.calls++
+	return f.sampled, f.tags
+}
+
+func (f *fakeDelegateSampler) Close() {}
+
+func (f *fakeDelegateSampler) Equal(other Sampler) bool {
+	o, ok := other.(*fakeDelegateSampler)
+	return ok && o == f
+}
+
+func TestPrioritySamplerIsSampledPassesThroughWithNoHint(t *testing.T) {
+	delegate := &fakeDelegateSampler{
+		sampled: true,
+		tags:    []Tag{{key: SamplerTypeTagKey, value: "custom"}},
+	}
+	s := NewPrioritySampler(delegate)
+
+	sampled, tags := s.IsSampled(1, "op")
+	if !sampled {
+		t.Fatal("expected the delegate's decision to pass through")
+	}
+	if len(tags) != 1 || tags[0].value != "custom" {
+		t.Fatalf("expected the delegate's tags to pass through, got %+v", tags)
+	}
+}
+
+func TestPrioritySamplerIsSampledWithContext(t *testing.T) {
+	tests := []struct {
+		name            string
+		delegateSampled bool
+		priority        int
+		wantSampled     bool
+		wantForcedTag   bool
+	}{
+		{"no hint, delegate samples", true, 0, true, false},
+		{"no hint, delegate drops", false, 0, false, false},
+		{"positive priority forces a sample over a drop", false, 1, true, true},
+		{"positive priority leaves a sample untouched", true, 1, true, true},
+		{"negative priority forces a drop over a sample", true, -1, false, true},
+		{"negative priority leaves a drop untouched", false, -1, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delegate := &fakeDelegateSampler{sampled: tt.delegateSampled}
+			s := NewPrioritySampler(delegate)
+
+			sampled, tags := s.IsSampledWithContext(1, "op", SamplingHints{Priority: tt.priority})
+
+			if sampled != tt.wantSampled {
+				t.Fatalf("expected sampled=%v, got %v", tt.wantSampled, sampled)
+			}
+			if delegate.calls != 1 {
+				t.Fatalf("expected the delegate to be consulted exactly once for accounting, got %d calls", delegate.calls)
+			}
+			gotForcedTag := len(tags) > 0 && tags[0].value == SamplerTypePriority
+			if gotForcedTag != tt.wantForcedTag {
+				t.Fatalf("expected forced tag=%v, got tags=%+v", tt.wantForcedTag, tags)
+			}
+		})
+	}
+}
+
+func TestRemotelyControlledSamplerIsSampledWithContextCachesWrapper(t *testing.T) {
+	delegate := &fakeDelegateSampler{sampled: true}
+	sampler := &RemotelyControlledSampler{sampler: delegate}
+
+	sampler.IsSampledWithContext(1, "op", SamplingHints{Priority: 1})
+	first := sampler.priorityWrapper
+
+	sampler.IsSampledWithContext(2, "op", SamplingHints{Priority: -1})
+	second := sampler.priorityWrapper
+
+	if first == nil || first != second {
+		t.Fatal("expected the PrioritySampler wrapper to be reused across calls for the same delegate")
+	}
+}
@@ -21,6 +21,7 @@
 package jaeger
 
 import (
+	"container/list"
 	"fmt"
 	"net/url"
 	"sync"
@@ -34,6 +35,8 @@ const (
 	defaultSamplingServerHostPort = "localhost:5778"
 
 	defaultMaxOperations = 2000
+
+	defaultSamplingRefreshInterval = time.Minute
 )
 
 // Sampler decides whether a new trace should be sampled or not.
@@ -269,19 +272,34 @@ func (s *GuaranteedThroughputProbabilisticSampler) update(lowerBound, samplingRa
 
 // -----------------------
 
+// adaptiveSamplerEntry is the value stored in adaptiveSampler's LRU list.
+type adaptiveSamplerEntry struct {
+	operation string
+	sampler   *GuaranteedThroughputProbabilisticSampler
+}
+
 type adaptiveSampler struct {
-	samplers                   map[string]*GuaranteedThroughputProbabilisticSampler
+	sync.Mutex
+
+	// samplers and lruList together form an LRU cache keyed on operation
+	// name: lruList orders operations from most- to least-recently sampled,
+	// and samplers maps an operation to its element in lruList.
+	samplers                   map[string]*list.Element
+	lruList                    *list.List
 	defaultSampler             Sampler
 	defaultSamplingProbability float64
 	lowerBound                 float64
 	maxOperations              int
+	metrics                    *Metrics
 }
 
 // NewAdaptiveSampler adaptiveSampler is a delegating sampler that applies both probabilisticSampler and
-// rateLimitingSampler via the guaranteedThroughputProbabilisticSampler. This sampler keeps track of all
-// operations and delegates calls to the respective guaranteedThroughputProbabilisticSampler.
-func NewAdaptiveSampler(strategies *sampling.PerOperationSamplingStrategies, maxOperations int) (Sampler, error) {
-	samplers := make(map[string]*GuaranteedThroughputProbabilisticSampler)
+// rateLimitingSampler via the guaranteedThroughputProbabilisticSampler. This sampler keeps track of up to
+// maxOperations operations in an LRU cache and delegates calls to the respective
+// guaranteedThroughputProbabilisticSampler, evicting the least-recently-sampled operation once the cache is full.
+func NewAdaptiveSampler(strategies *sampling.PerOperationSamplingStrategies, maxOperations int, metrics *Metrics) (Sampler, error) {
+	samplers := make(map[string]*list.Element)
+	lruList := list.New()
 	for _, strategy := range strategies.PerOperationStrategies {
 		sampler, err := NewGuaranteedThroughputProbabilisticSampler(
 			strategy.Operation,
@@ -291,7 +309,16 @@ func NewAdaptiveSampler(strategies *sampling.PerOperationSamplingStrategies, max
 		if err != nil {
 			return nil, err
 		}
-		samplers[strategy.Operation] = sampler
+		if maxOperations > 0 && lruList.Len() >= maxOperations {
+			oldest := lruList.Back()
+			entry := lruList.Remove(oldest).(*adaptiveSamplerEntry)
+			delete(samplers, entry.operation)
+			entry.sampler.Close()
+		}
+		samplers[strategy.Operation] = lruList.PushFront(&adaptiveSamplerEntry{
+			operation: strategy.Operation,
+			sampler:   sampler,
+		})
 	}
 	defaultSampler, err := NewProbabilisticSampler(strategies.DefaultSamplingProbability)
 	if err != nil {
@@ -299,33 +326,58 @@ func NewAdaptiveSampler(strategies *sampling.PerOperationSamplingStrategies, max
 	}
 	return &adaptiveSampler{
 		samplers:                   samplers,
+		lruList:                    lruList,
 		defaultSampler:             defaultSampler,
 		defaultSamplingProbability: strategies.DefaultSamplingProbability,
 		lowerBound:                 strategies.DefaultLowerBoundTracesPerSecond,
 		maxOperations:              maxOperations,
+		metrics:                    metrics,
 	}, nil
 }
 
 func (s *adaptiveSampler) IsSampled(id uint64, operation string) (bool, []Tag) {
-	sampler, ok := s.samplers[operation]
-	if !ok {
-		if len(s.samplers) >= s.maxOperations {
-			// Store only up to maxOperations of unique ops.
-			return s.defaultSampler.IsSampled(id, operation)
-		}
-		sampler, err := NewGuaranteedThroughputProbabilisticSampler(operation, s.lowerBound, s.defaultSamplingProbability)
-		if err != nil {
-			return false, nil
-		}
-		s.samplers[operation] = sampler
+	s.Lock()
+	if elem, ok := s.samplers[operation]; ok {
+		s.lruList.MoveToFront(elem)
+		sampler := elem.Value.(*adaptiveSamplerEntry).sampler
+		s.Unlock()
 		return sampler.IsSampled(id, operation)
 	}
+	s.evictIfFullLocked()
+	sampler, err := NewGuaranteedThroughputProbabilisticSampler(operation, s.lowerBound, s.defaultSamplingProbability)
+	if err != nil {
+		s.Unlock()
+		return false, nil
+	}
+	s.samplers[operation] = s.lruList.PushFront(&adaptiveSamplerEntry{operation: operation, sampler: sampler})
+	s.Unlock()
 	return sampler.IsSampled(id, operation)
 }
 
+// evictIfFullLocked evicts the least-recently-sampled operation once the LRU is at capacity.
+// Callers must hold s.Lock().
+func (s *adaptiveSampler) evictIfFullLocked() {
+	if s.lruList.Len() < s.maxOperations {
+		return
+	}
+	oldest := s.lruList.Back()
+	if oldest == nil {
+		return
+	}
+	entry := oldest.Value.(*adaptiveSamplerEntry)
+	s.lruList.Remove(oldest)
+	delete(s.samplers, entry.operation)
+	entry.sampler.Close()
+	if s.metrics != nil {
+		s.metrics.SamplerOperationsEvicted.Inc(1)
+	}
+}
+
 func (s *adaptiveSampler) Close() {
-	for _, sampler := range s.samplers {
-		sampler.Close()
+	s.Lock()
+	defer s.Unlock()
+	for elem := s.lruList.Front(); elem != nil; elem = elem.Next() {
+		elem.Value.(*adaptiveSamplerEntry).sampler.Close()
 	}
 }
 
@@ -340,12 +392,16 @@ func (s *adaptiveSampler) Equal(other Sampler) bool {
 
 // this function should only be called while holding a Write lock
 func (s *adaptiveSampler) update(strategies *sampling.PerOperationSamplingStrategies) error {
+	s.Lock()
+	defer s.Unlock()
 	for _, strategy := range strategies.PerOperationStrategies {
 		operation := strategy.Operation
 		samplingRate := strategy.ProbabilisticSampling.SamplingRate
 		lowerBound := strategies.DefaultLowerBoundTracesPerSecond
-		if sampler, ok := s.samplers[operation]; ok {
-			if err := sampler.update(lowerBound, samplingRate); err != nil {
+		if elem, ok := s.samplers[operation]; ok {
+			// Merging a known strategy updates it in place without touching its
+			// position in the LRU; recency is only earned via IsSampled.
+			if err := elem.Value.(*adaptiveSamplerEntry).sampler.update(lowerBound, samplingRate); err != nil {
 				return err
 			}
 		} else {
@@ -357,7 +413,8 @@ func (s *adaptiveSampler) update(strategies *sampling.PerOperationSamplingStrate
 			if err != nil {
 				return err
 			}
-			s.samplers[operation] = sampler
+			s.evictIfFullLocked()
+			s.samplers[operation] = s.lruList.PushFront(&adaptiveSamplerEntry{operation: operation, sampler: sampler})
 		}
 	}
 	s.lowerBound = strategies.DefaultLowerBoundTracesPerSecond
@@ -385,11 +442,16 @@ type RemotelyControlledSampler struct {
 	manager     sampling.SamplingManager
 	pollStopped sync.WaitGroup
 
-	hostPort      string
-	logger        Logger
-	sampler       Sampler
-	metrics       *Metrics
-	maxOperations int
+	hostPort        string
+	logger          Logger
+	sampler         Sampler
+	metrics         *Metrics
+	maxOperations   int
+	refreshInterval time.Duration
+
+	priorityMu      sync.Mutex
+	priorityWrapped Sampler
+	priorityWrapper *PrioritySampler
 }
 
 type httpSamplingManager struct {
@@ -414,17 +476,20 @@ func NewRemotelyControlledSampler(
 ) *RemotelyControlledSampler {
 	initialSampler, _ := NewProbabilisticSampler(0.001)
 	sampler := &RemotelyControlledSampler{
-		serviceName:   serviceName,
-		logger:        NullLogger,
-		metrics:       NewMetrics(NullStatsReporter, nil),
-		timer:         time.NewTicker(1 * time.Minute),
-		hostPort:      defaultSamplingServerHostPort,
-		sampler:       initialSampler,
-		maxOperations: defaultMaxOperations,
+		serviceName:     serviceName,
+		logger:          NullLogger,
+		metrics:         NewMetrics(NullStatsReporter, nil),
+		hostPort:        defaultSamplingServerHostPort,
+		sampler:         initialSampler,
+		maxOperations:   defaultMaxOperations,
+		refreshInterval: defaultSamplingRefreshInterval,
 	}
 
 	sampler.applyOptions(options...)
-	sampler.manager = &httpSamplingManager{serverURL: "http://" + sampler.hostPort}
+	if sampler.manager == nil {
+		sampler.manager = &httpSamplingManager{serverURL: "http://" + sampler.hostPort}
+	}
+	sampler.timer = time.NewTicker(sampler.refreshInterval)
 
 	go sampler.pollController()
 	return sampler
@@ -450,6 +515,12 @@ func (s *RemotelyControlledSampler) applyOptions(options ...SamplerOption) {
 	if opts.metrics != nil {
 		s.metrics = opts.metrics
 	}
+	if opts.manager != nil {
+		s.manager = opts.manager
+	}
+	if opts.refreshInterval > 0 {
+		s.refreshInterval = opts.refreshInterval
+	}
 }
 
 // IsSampled implements IsSampled() of Sampler.
@@ -459,6 +530,36 @@ func (s *RemotelyControlledSampler) IsSampled(id uint64, operation string) (bool
 	return s.sampler.IsSampled(id, operation)
 }
 
+// IsSampledWithContext implements ContextualSampler, letting a caller pass a
+// SamplingHints priority discovered mid-span (e.g. from a sampling.priority
+// tag) through to the currently active delegate sampler.
+func (s *RemotelyControlledSampler) IsSampledWithContext(id uint64, operation string, hints SamplingHints) (bool, []Tag) {
+	s.RLock()
+	sampler := s.sampler
+	s.RUnlock()
+	if hints.Priority == 0 {
+		return sampler.IsSampled(id, operation)
+	}
+	if contextual, ok := sampler.(ContextualSampler); ok {
+		return contextual.IsSampledWithContext(id, operation, hints)
+	}
+	return s.priorityWrapperFor(sampler).IsSampledWithContext(id, operation, hints)
+}
+
+// priorityWrapperFor returns a PrioritySampler wrapping delegate, reusing the
+// wrapper created for the previous call when delegate hasn't changed since
+// then, so forcing a priority decision doesn't allocate a new wrapper on
+// every span.
+func (s *RemotelyControlledSampler) priorityWrapperFor(delegate Sampler) *PrioritySampler {
+	s.priorityMu.Lock()
+	defer s.priorityMu.Unlock()
+	if s.priorityWrapped != delegate {
+		s.priorityWrapped = delegate
+		s.priorityWrapper = NewPrioritySampler(delegate)
+	}
+	return s.priorityWrapper
+}
+
 // Close implements Close() of Sampler.
 func (s *RemotelyControlledSampler) Close() {
 	s.RLock()
@@ -533,7 +634,7 @@ func (s *RemotelyControlledSampler) extractSampler(
 		if sampler, ok := s.sampler.(*adaptiveSampler); ok {
 			return sampler, strategies, nil
 		}
-		sampler, err := NewAdaptiveSampler(strategies, s.maxOperations)
+		sampler, err := NewAdaptiveSampler(strategies, s.maxOperations, s.metrics)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package jaeger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uber/jaeger-client-go/thrift-gen/sampling"
+)
+
+// fakeSamplingManager is an in-memory sampling.SamplingManager used to drive
+// RemotelyControlledSampler's poll loop without a real jaeger-agent.
+type fakeSamplingManager struct {
+	strategy *sampling.SamplingStrategyResponse
+}
+
+func (f *fakeSamplingManager) GetSamplingStrategy(serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	return f.strategy, nil
+}
+
+func TestRemotelyControlledSamplerWithInMemoryManager(t *testing.T) {
+	fake := &fakeSamplingManager{
+		strategy: &sampling.SamplingStrategyResponse{
+			StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+				SamplingRate: 1.0,
+			},
+		},
+	}
+	sampler := NewRemotelyControlledSampler(
+		"test-service",
+		// Start from a sampler that drops everything, so a positive decision
+		// can only come from the strategy fetched by the poll loop.
+		SamplerOptions.Sampler(NewConstSampler(false)),
+		SamplerOptions.Manager(fake),
+		SamplerOptions.SamplingRefreshInterval(time.Millisecond),
+	)
+	defer sampler.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if sampled, _ := sampler.IsSampled(1, "op"); sampled {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("expected sampler to pick up the strategy from the injected fake manager via the poll loop")
+}